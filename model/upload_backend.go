@@ -0,0 +1,28 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "io"
+
+// UploadBackend abstracts the object store operations needed to stream an
+// UploadSession's chunks directly into a native multipart upload, bypassing
+// the local disk. Implementations are provided by the app layer for the
+// file stores (e.g. S3, GCS) that support it.
+type UploadBackend interface {
+	// CreateMultipartUpload starts a new multipart upload for path and
+	// returns the backend-assigned upload id.
+	CreateMultipartUpload(path string) (string, *AppError)
+
+	// UploadPart streams data as the given part number of the multipart
+	// upload identified by backendId and returns the resulting UploadPart.
+	UploadPart(backendId, path string, partNumber int, data io.Reader) (UploadPart, *AppError)
+
+	// CompleteMultipartUpload finalizes the multipart upload identified by
+	// backendId, assembling the given parts into a single object.
+	CompleteMultipartUpload(backendId, path string, parts []UploadPart) *AppError
+
+	// AbortMultipartUpload discards the multipart upload identified by
+	// backendId, releasing any parts already uploaded.
+	AbortMultipartUpload(backendId, path string) *AppError
+}
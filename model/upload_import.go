@@ -0,0 +1,54 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// UploadImportStage represents the current stage of a background import
+// job started from a completed UploadTypeImport upload.
+type UploadImportStage string
+
+const (
+	UploadImportStageValidating UploadImportStage = "validating"
+	UploadImportStageRunning    UploadImportStage = "running"
+	UploadImportStageDone       UploadImportStage = "done"
+	UploadImportStageFailed     UploadImportStage = "failed"
+)
+
+// UploadImportProgress reports the progress of the import job tied to an
+// UploadSession's ImportJobId. It is returned by the import-status endpoint
+// and pushed over the WebSocket as the job advances.
+type UploadImportProgress struct {
+	// The id of the upload session the import job was created from.
+	UploadId string `json:"upload_id"`
+	// The id of the background job doing the import.
+	JobId string `json:"job_id"`
+	// The current stage of the import.
+	Stage UploadImportStage `json:"stage"`
+	// The number of manifest lines processed so far.
+	LinesProcessed int64 `json:"lines_processed"`
+	// Non-fatal errors encountered while processing the manifest.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ToJson serializes the UploadImportProgress into JSON and returns it as a
+// string.
+func (p *UploadImportProgress) ToJson() string {
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+// UploadImportProgressFromJson deserializes an UploadImportProgress from
+// JSON data.
+func UploadImportProgressFromJson(data io.Reader) *UploadImportProgress {
+	decoder := json.NewDecoder(data)
+	var p UploadImportProgress
+	if err := decoder.Decode(&p); err != nil {
+		return nil
+	}
+	return &p
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadImportProgressJson(t *testing.T) {
+	p := &UploadImportProgress{
+		UploadId:       NewId(),
+		JobId:          NewId(),
+		Stage:          UploadImportStageRunning,
+		LinesProcessed: 42,
+		Errors:         []string{"line 7: invalid user id"},
+	}
+
+	got := UploadImportProgressFromJson(strings.NewReader(p.ToJson()))
+	require.NotNil(t, got)
+	require.Equal(t, p, got)
+}
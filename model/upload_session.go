@@ -4,6 +4,7 @@
 package model
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -16,8 +17,14 @@ type UploadType string
 const (
 	UploadTypeAttachment UploadType = "attachment"
 	UploadTypeImport     UploadType = "import"
+	UploadTypeTus        UploadType = "tus"
+	UploadTypeDirect     UploadType = "direct"
 )
 
+// TusResumable is the value of the Tus-Resumable header understood by the
+// upload endpoints.
+const TusResumable = "1.0.0"
+
 // UploadSession contains information used to keep track of a file upload.
 type UploadSession struct {
 	// The unique identifier for the session.
@@ -39,8 +46,53 @@ type UploadSession struct {
 	// The amount of received data in bytes. If equal to FileSize it means the
 	// upload has finished.
 	FileOffset int64 `json:"file_offset"`
+	// Arbitrary key/value pairs supplied by the client on creation, carried
+	// over from the tus.io Upload-Metadata header.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// The timestamp after which an incomplete upload session may be
+	// discarded, mirroring tus.io's notion of upload expiration.
+	Expires int64 `json:"expires,omitempty"`
+	// The SHA-256 hash (hex-encoded) of the uploaded file, used to
+	// de-duplicate identical blobs and to let clients verify the integrity
+	// of the data they sent.
+	Hash string `json:"hash,omitempty"`
+	// The identifier of the in-progress multipart upload on the backing
+	// object store (e.g. an S3 UploadId), when the upload is streamed
+	// directly to an UploadBackend instead of local disk.
+	BackendId string `json:"backend_id,omitempty"`
+	// The parts accepted so far by the object store for a multipart
+	// upload.
+	Parts []UploadPart `json:"parts,omitempty"`
+	// A pre-signed URL the client can PUT the file to directly, bypassing
+	// the app server. Only set for UploadTypeDirect and never persisted
+	// past the lifetime of the session.
+	UploadURL string `json:"upload_url,omitempty"`
+	// The timestamp after which UploadURL is no longer valid and a new one
+	// must be requested.
+	UploadURLExpiresAt int64 `json:"upload_url_expires_at,omitempty"`
+	// The id of the background job processing the archive once a
+	// UploadTypeImport upload has completed.
+	ImportJobId string `json:"import_job_id,omitempty"`
+	// Options controlling how the import is processed (e.g. dry-run, team
+	// override), keyed by option name.
+	ImportOptions map[string]string `json:"import_options,omitempty"`
+}
+
+// UploadPart describes a single part of a multipart upload that has already
+// been accepted by an UploadBackend.
+type UploadPart struct {
+	// The 1-based, monotonically increasing part number.
+	Number int `json:"number"`
+	// The ETag returned by the backend for this part.
+	ETag string `json:"etag"`
+	// The size in bytes of this part.
+	Size int64 `json:"size"`
 }
 
+// MinUploadPartSize is the minimum size, in bytes, of a multipart upload
+// part, except for the last part of an upload.
+const MinUploadPartSize = 5 * 1024 * 1024
+
 // ToJson serializes the UploadSession into JSON and returns it as string.
 func (us *UploadSession) ToJson() string {
 	b, _ := json.Marshal(us)
@@ -93,6 +145,10 @@ func (t UploadType) IsValid() error {
 		return nil
 	case UploadTypeImport:
 		return nil
+	case UploadTypeTus:
+		return nil
+	case UploadTypeDirect:
+		return nil
 	default:
 	}
 	return fmt.Errorf("invalid UploadType %s", t)
@@ -137,5 +193,58 @@ func (us *UploadSession) IsValid() *AppError {
 		return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.path.app_error", nil, "id="+us.Id, http.StatusBadRequest)
 	}
 
+	if us.Expires != 0 && us.Expires <= us.CreateAt {
+		return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.expires.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+	}
+
+	// Hash is only populated by the content-addressable dedup path, so it
+	// is optional; when present (on any upload, complete or not) its
+	// format is still checked so a corrupt value can never be persisted.
+	if us.Hash != "" && !isValidSHA256Hash(us.Hash) {
+		return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.hash.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+	}
+
+	if us.Type != UploadTypeDirect && (us.UploadURL != "" || us.UploadURLExpiresAt != 0) {
+		return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.upload_url.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+	}
+
+	if us.Type == UploadTypeImport {
+		if us.ImportJobId != "" && !IsValidId(us.ImportJobId) {
+			return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.import_job_id.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+		}
+	} else if us.ImportJobId != "" || len(us.ImportOptions) > 0 {
+		return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.import_job_id.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+	}
+
+	if len(us.Parts) > 0 && us.BackendId == "" {
+		return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.parts.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+	}
+
+	for i, part := range us.Parts {
+		if part.Number != i+1 {
+			return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.parts.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+		}
+		if part.ETag == "" || part.Size <= 0 {
+			return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.parts.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+		}
+		if part.Size < MinUploadPartSize && i != len(us.Parts)-1 {
+			return NewAppError("UploadSession.IsValid", "model.upload_session.is_valid.parts.app_error", nil, "id="+us.Id, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
+
+// isValidSHA256Hash returns true if s is a lower-case hex encoded SHA-256
+// digest.
+func isValidSHA256Hash(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validUploadSession() *UploadSession {
+	return &UploadSession{
+		Id:         NewId(),
+		Type:       UploadTypeAttachment,
+		CreateAt:   GetMillis(),
+		UserId:     NewId(),
+		ChannelId:  NewId(),
+		Filename:   "test.png",
+		Path:       "/tmp/data/test.png",
+		FileSize:   1024,
+		FileOffset: 0,
+	}
+}
+
+func TestUploadSessionIsValid(t *testing.T) {
+	t.Run("valid attachment session", func(t *testing.T) {
+		us := validUploadSession()
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("valid tus session with metadata and expiry", func(t *testing.T) {
+		us := validUploadSession()
+		us.Type = UploadTypeTus
+		us.ChannelId = ""
+		us.Metadata = map[string]string{"filetype": "image/png"}
+		us.Expires = us.CreateAt + 1000
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("expires before create_at is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.Expires = us.CreateAt - 1000
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("expires equal to create_at is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.Expires = us.CreateAt
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("zero expires is valid (no expiry)", func(t *testing.T) {
+		us := validUploadSession()
+		us.Expires = 0
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("completed upload without a hash is still valid", func(t *testing.T) {
+		us := validUploadSession()
+		us.FileOffset = us.FileSize
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("malformed hash is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.Hash = "not-a-sha256-hash"
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("valid sha256 hash is valid", func(t *testing.T) {
+		us := validUploadSession()
+		us.FileOffset = us.FileSize
+		us.Hash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("valid multipart backend session", func(t *testing.T) {
+		us := validUploadSession()
+		us.BackendId = "s3-upload-id"
+		us.Parts = []UploadPart{
+			{Number: 1, ETag: "etag1", Size: MinUploadPartSize},
+			{Number: 2, ETag: "etag2", Size: 1024},
+		}
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("parts without a backend id is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.Parts = []UploadPart{{Number: 1, ETag: "etag1", Size: 1024}}
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("out of order part numbers is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.BackendId = "s3-upload-id"
+		us.Parts = []UploadPart{
+			{Number: 2, ETag: "etag1", Size: MinUploadPartSize},
+			{Number: 1, ETag: "etag2", Size: 1024},
+		}
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("part with empty etag is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.BackendId = "s3-upload-id"
+		us.Parts = []UploadPart{{Number: 1, ETag: "", Size: MinUploadPartSize}}
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("part with non-positive size is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.BackendId = "s3-upload-id"
+		us.Parts = []UploadPart{{Number: 1, ETag: "etag1", Size: 0}}
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("non-last part below minimum size is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.BackendId = "s3-upload-id"
+		us.Parts = []UploadPart{
+			{Number: 1, ETag: "etag1", Size: 1024},
+			{Number: 2, ETag: "etag2", Size: 1024},
+		}
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("valid direct upload session", func(t *testing.T) {
+		us := validUploadSession()
+		us.Type = UploadTypeDirect
+		us.ChannelId = ""
+		us.UploadURL = "https://bucket.s3.amazonaws.com/path?signature=..."
+		us.UploadURLExpiresAt = us.CreateAt + 1000
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("upload url on a non-direct session is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.UploadURL = "https://bucket.s3.amazonaws.com/path?signature=..."
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("upload url expiry on a non-direct session is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.UploadURLExpiresAt = us.CreateAt + 1000
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("valid import session", func(t *testing.T) {
+		us := validUploadSession()
+		us.Type = UploadTypeImport
+		us.ChannelId = ""
+		us.ImportJobId = NewId()
+		us.ImportOptions = map[string]string{"dry_run": "true"}
+		require.Nil(t, us.IsValid())
+	})
+
+	t.Run("malformed import job id is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.Type = UploadTypeImport
+		us.ChannelId = ""
+		us.ImportJobId = "not-an-id"
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("import job id on a non-import session is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.ImportJobId = NewId()
+		require.NotNil(t, us.IsValid())
+	})
+
+	t.Run("import options on a non-import session is invalid", func(t *testing.T) {
+		us := validUploadSession()
+		us.ImportOptions = map[string]string{"dry_run": "true"}
+		require.NotNil(t, us.IsValid())
+	})
+}